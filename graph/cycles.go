@@ -0,0 +1,137 @@
+package graph
+
+import "github.com/google/uuid"
+
+// Edge is a directed edge, used by FindLoopEdges to report which edges
+// close a cycle.
+type Edge struct {
+	From, To Node
+}
+
+// tarjanFrame is one level of the explicit DFS stack that replaces
+// recursion in tarjanSCCs, so arbitrarily deep graphs don't blow the Go
+// call stack.
+type tarjanFrame struct {
+	node     Node
+	children []Node
+	ci       int
+}
+
+// tarjanSCCs runs Tarjan's strongly-connected-components algorithm over g
+// via an iterative DFS, and returns every SCC of size > 1 (or containing a
+// self-loop) alongside every back edge found while exploring (an edge into
+// a node that is still on the DFS stack).
+//
+// Bookkeeping is keyed by uuid.UUID rather than the Node value itself, the
+// same way TopologicalSortStable is, since a Node isn't guaranteed to be
+// comparable (task.Task embeds a []Actionable).
+func tarjanSCCs(g GraphOperable) ([][]Node, []Edge) {
+	index := make(map[uuid.UUID]int)
+	lowlink := make(map[uuid.UUID]int)
+	onStack := make(map[uuid.UUID]bool)
+	onPath := make(map[uuid.UUID]bool) // nodes with a frame on the DFS stack
+	var stack []Node
+	var sccs [][]Node
+	var backEdges []Edge
+	counter := 0
+
+	visit := func(start Node) {
+		work := []*tarjanFrame{{node: start, children: g.GetAdjacencyFromNode(start)}}
+		index[start.Id()] = counter
+		lowlink[start.Id()] = counter
+		counter++
+		stack = append(stack, start)
+		onStack[start.Id()] = true
+		onPath[start.Id()] = true
+
+		for len(work) > 0 {
+			top := work[len(work)-1]
+
+			if top.ci < len(top.children) {
+				w := top.children[top.ci]
+				top.ci++
+
+				if _, seen := index[w.Id()]; !seen {
+					index[w.Id()] = counter
+					lowlink[w.Id()] = counter
+					counter++
+					stack = append(stack, w)
+					onStack[w.Id()] = true
+					onPath[w.Id()] = true
+					work = append(work, &tarjanFrame{node: w, children: g.GetAdjacencyFromNode(w)})
+				} else if onStack[w.Id()] {
+					if index[w.Id()] < lowlink[top.node.Id()] {
+						lowlink[top.node.Id()] = index[w.Id()]
+					}
+					if onPath[w.Id()] {
+						backEdges = append(backEdges, Edge{From: top.node, To: w})
+					}
+				}
+				continue
+			}
+
+			work = work[:len(work)-1]
+			onPath[top.node.Id()] = false
+			if len(work) > 0 {
+				parent := work[len(work)-1]
+				if lowlink[top.node.Id()] < lowlink[parent.node.Id()] {
+					lowlink[parent.node.Id()] = lowlink[top.node.Id()]
+				}
+			}
+
+			if lowlink[top.node.Id()] == index[top.node.Id()] {
+				var scc []Node
+				for {
+					n := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack[n.Id()] = false
+					scc = append(scc, n)
+					if n.Id() == top.node.Id() {
+						break
+					}
+				}
+				if len(scc) > 1 || g.HasEdge(scc[0], scc[0]) {
+					sccs = append(sccs, scc)
+				}
+			}
+		}
+	}
+
+	for _, v := range g.GetAllNodes() {
+		if _, seen := index[v.Id()]; !seen {
+			visit(v)
+		}
+	}
+
+	return sccs, backEdges
+}
+
+// FindCycles reports every strongly-connected component of g with more
+// than one node, plus any node with a self-loop, using Tarjan's algorithm.
+// A GraphOperable with no cycles yields an empty slice.
+func FindCycles(g GraphOperable) [][]Node {
+	sccs, _ := tarjanSCCs(g)
+	return sccs
+}
+
+// FindLoopEdges returns the edges that close a cycle in g: the back edges
+// Tarjan's DFS discovers that stay within a strongly-connected component.
+// Removing them breaks every SCC FindCycles would otherwise report.
+func FindLoopEdges(g GraphOperable) []Edge {
+	sccs, backEdges := tarjanSCCs(g)
+
+	inCycle := make(map[uuid.UUID]bool)
+	for _, scc := range sccs {
+		for _, n := range scc {
+			inCycle[n.Id()] = true
+		}
+	}
+
+	loopEdges := make([]Edge, 0, len(backEdges))
+	for _, e := range backEdges {
+		if inCycle[e.From.Id()] && inCycle[e.To.Id()] {
+			loopEdges = append(loopEdges, e)
+		}
+	}
+	return loopEdges
+}
@@ -0,0 +1,81 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindCyclesReportsSCCAndLeavesAcyclicNodesOut(t *testing.T) {
+	a, b, c, d := namedNode("A"), namedNode("B"), namedNode("C"), namedNode("D")
+
+	g := NewSimpleDigraph()
+	g.AddNode(a)
+	g.AddNode(b)
+	g.AddNode(c)
+	g.AddNode(d)
+	g.AddEdge(a, b)
+	g.AddEdge(b, c)
+	g.AddEdge(c, a) // closes A -> B -> C -> A
+	g.AddEdge(c, d) // D is downstream but not part of the cycle
+
+	sccs := FindCycles(g)
+	if len(sccs) != 1 {
+		t.Fatalf("expected exactly one SCC, got %d: %v", len(sccs), sccs)
+	}
+	if len(sccs[0]) != 3 {
+		t.Fatalf("expected the SCC to contain 3 nodes, got %d", len(sccs[0]))
+	}
+
+	inSCC := make(map[string]bool)
+	for _, n := range sccs[0] {
+		inSCC[n.Label()] = true
+	}
+	for _, label := range []string{"A", "B", "C"} {
+		if !inSCC[label] {
+			t.Errorf("expected %s to be reported as part of the cycle", label)
+		}
+	}
+	if inSCC["D"] {
+		t.Errorf("D is not part of any cycle and should not be reported")
+	}
+
+	loopEdges := FindLoopEdges(g)
+	if len(loopEdges) == 0 {
+		t.Fatalf("expected at least one loop edge")
+	}
+	for _, e := range loopEdges {
+		if !inSCC[e.From.Label()] || !inSCC[e.To.Label()] {
+			t.Errorf("loop edge %v->%v has an endpoint outside the cycle", e.From.Label(), e.To.Label())
+		}
+	}
+}
+
+func TestFindCyclesReportsSelfLoop(t *testing.T) {
+	a := namedNode("A")
+	g := NewSimpleDigraph()
+	g.AddNode(a)
+	g.AddEdge(a, a)
+
+	sccs := FindCycles(g)
+	if len(sccs) != 1 || len(sccs[0]) != 1 {
+		t.Fatalf("expected a single-node self-loop SCC, got %v", sccs)
+	}
+}
+
+func TestLoadDOTAcceptsTrailingEdgeAttributes(t *testing.T) {
+	dot := `digraph G {
+  rankdir=LR;
+  node [shape=box, style=rounded, fontsize=12];
+  0 [label="A"];
+  1 [label="B"];
+  0 -> 1 [color=red];
+}
+`
+	g, byLabel, _, err := LoadDOT(strings.NewReader(dot))
+	if err != nil {
+		t.Fatalf("LoadDOT returned error: %v", err)
+	}
+	if !g.HasEdge(byLabel["A"], byLabel["B"]) {
+		t.Fatalf("expected the red-colored edge A -> B to still be imported")
+	}
+}
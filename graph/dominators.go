@@ -0,0 +1,172 @@
+package graph
+
+import "github.com/google/uuid"
+
+// dfsFrame is one level of the explicit DFS stack used to number nodes in
+// pre-order without recursion, mirroring tarjanFrame in cycles.go.
+type dfsFrame struct {
+	node     Node
+	children []Node
+	ci       int
+}
+
+// dfsPreorder numbers every node reachable from root in DFS pre-order and
+// records each one's DFS-tree parent, as Lengauer-Tarjan needs both. numOf
+// is keyed by uuid.UUID rather than the Node value itself, the same way
+// TopologicalSortStable and tarjanSCCs are, since a Node isn't guaranteed to
+// be comparable (task.Task embeds a []Actionable).
+func dfsPreorder(root Node, g GraphOperable) (vertex []Node, numOf map[uuid.UUID]int, parent []int) {
+	numOf = make(map[uuid.UUID]int)
+	numOf[root.Id()] = 0
+	vertex = append(vertex, root)
+	parent = append(parent, -1)
+
+	work := []*dfsFrame{{node: root, children: g.GetAdjacencyFromNode(root)}}
+	for len(work) > 0 {
+		top := work[len(work)-1]
+		if top.ci >= len(top.children) {
+			work = work[:len(work)-1]
+			continue
+		}
+		w := top.children[top.ci]
+		top.ci++
+		if _, seen := numOf[w.Id()]; seen {
+			continue
+		}
+		numOf[w.Id()] = len(vertex)
+		vertex = append(vertex, w)
+		parent = append(parent, numOf[top.node.Id()])
+		work = append(work, &dfsFrame{node: w, children: g.GetAdjacencyFromNode(w)})
+	}
+
+	return vertex, numOf, parent
+}
+
+// Dominators computes the immediate dominator of every node reachable from
+// root via the Lengauer-Tarjan algorithm: a DFS numbers nodes in pre-order,
+// semidominators are computed with a union-find "link/eval" structure that
+// returns, for an ancestor chain, the node whose semidominator has the
+// smallest DFS number, and immediate dominators are then read off the
+// semidominator buckets during the same backward pass and finalized in a
+// second, forward pass. root itself has no entry in the result.
+func Dominators(root Node, g GraphOperable) map[uuid.UUID]Node {
+	vertex, numOf, parent := dfsPreorder(root, g)
+	n := len(vertex)
+
+	pred := make([][]int, n)
+	for _, v := range vertex {
+		vi := numOf[v.Id()]
+		for _, w := range g.GetAdjacencyFromNode(v) {
+			if wi, ok := numOf[w.Id()]; ok {
+				pred[wi] = append(pred[wi], vi)
+			}
+		}
+	}
+
+	semi := make([]int, n)
+	for i := range semi {
+		semi[i] = i
+	}
+	idom := make([]int, n)
+	ancestor := make([]int, n)
+	label := make([]int, n)
+	for i := range label {
+		label[i] = i
+		ancestor[i] = -1
+	}
+	bucket := make([][]int, n)
+
+	var compress func(v int)
+	compress = func(v int) {
+		a := ancestor[v]
+		if a == -1 || ancestor[a] == -1 {
+			return
+		}
+		compress(a)
+		if semi[label[a]] < semi[label[v]] {
+			label[v] = label[a]
+		}
+		ancestor[v] = ancestor[a]
+	}
+	eval := func(v int) int {
+		if ancestor[v] == -1 {
+			return v
+		}
+		compress(v)
+		return label[v]
+	}
+
+	for w := n - 1; w >= 1; w-- {
+		for _, v := range pred[w] {
+			u := eval(v)
+			if semi[u] < semi[w] {
+				semi[w] = semi[u]
+			}
+		}
+		bucket[semi[w]] = append(bucket[semi[w]], w)
+		ancestor[w] = parent[w] // link(parent[w], w)
+
+		pw := parent[w]
+		for _, v := range bucket[pw] {
+			if u := eval(v); semi[u] < semi[v] {
+				idom[v] = u
+			} else {
+				idom[v] = pw
+			}
+		}
+		bucket[pw] = nil
+	}
+
+	for w := 1; w < n; w++ {
+		if idom[w] != semi[w] {
+			idom[w] = idom[idom[w]]
+		}
+	}
+
+	result := make(map[uuid.UUID]Node, n-1)
+	for w := 1; w < n; w++ {
+		result[vertex[w].Id()] = vertex[idom[w]]
+	}
+	return result
+}
+
+// DominatorTree builds the dominator tree of g rooted at root: an edge
+// idom(v) -> v for every node v reachable from root other than root
+// itself.
+func DominatorTree(root Node, g GraphOperable) *SimpleDigraph {
+	idom := Dominators(root, g)
+
+	tree := NewSimpleDigraph()
+	tree.AddNode(root)
+	for _, n := range g.GetAllNodes() {
+		if _, ok := idom[n.Id()]; ok {
+			tree.AddNode(n)
+		}
+	}
+	for _, n := range g.GetAllNodes() {
+		if parentNode, ok := idom[n.Id()]; ok {
+			tree.AddEdge(parentNode, n)
+		}
+	}
+	return tree
+}
+
+// Dominates reports whether a dominates b: every path from root to b
+// passes through a. idom is the map returned by Dominators for that root.
+// Every node dominates itself.
+func Dominates(idom map[uuid.UUID]Node, a, b Node) bool {
+	if a.Id() == b.Id() {
+		return true
+	}
+	cur := b
+	for {
+		parentNode, ok := idom[cur.Id()]
+		if !ok {
+			return false
+		}
+		if parentNode.Id() == a.Id() {
+			return true
+		}
+		cur = parentNode
+	}
+}
@@ -0,0 +1,82 @@
+package graph
+
+import "testing"
+
+// Diamond graph:
+//
+//	root -> a -> c -> d
+//	root -> b -> c
+//
+// c merges two paths from root, so root (not a or b) is its immediate
+// dominator; d is only reachable through c, so c is d's immediate
+// dominator.
+func TestDominatorsOnDiamond(t *testing.T) {
+	root, a, b, c, d := namedNode("root"), namedNode("a"), namedNode("b"), namedNode("c"), namedNode("d")
+
+	g := NewSimpleDigraph()
+	for _, n := range []Node{root, a, b, c, d} {
+		g.AddNode(n)
+	}
+	g.AddEdge(root, a)
+	g.AddEdge(root, b)
+	g.AddEdge(a, c)
+	g.AddEdge(b, c)
+	g.AddEdge(c, d)
+
+	idom := Dominators(root, g)
+
+	cases := []struct {
+		node Node
+		want Node
+	}{
+		{a, root},
+		{b, root},
+		{c, root},
+		{d, c},
+	}
+	for _, tc := range cases {
+		got, ok := idom[tc.node.Id()]
+		if !ok {
+			t.Fatalf("no idom recorded for %s", tc.node.Label())
+		}
+		if got.Id() != tc.want.Id() {
+			t.Errorf("idom(%s) = %s, want %s", tc.node.Label(), got.Label(), tc.want.Label())
+		}
+	}
+	if _, ok := idom[root.Id()]; ok {
+		t.Errorf("root should have no idom entry")
+	}
+
+	if !Dominates(idom, root, d) {
+		t.Errorf("expected root to dominate d")
+	}
+	if !Dominates(idom, c, d) {
+		t.Errorf("expected c to dominate d")
+	}
+	if Dominates(idom, a, d) {
+		t.Errorf("a does not dominate d: b->c->d bypasses a")
+	}
+	if !Dominates(idom, d, d) {
+		t.Errorf("expected every node to dominate itself")
+	}
+}
+
+func TestDominatorTreeMatchesIdom(t *testing.T) {
+	root, x, y := namedNode("root"), namedNode("x"), namedNode("y")
+
+	g := NewSimpleDigraph()
+	g.AddNode(root)
+	g.AddNode(x)
+	g.AddNode(y)
+	g.AddEdge(root, x)
+	g.AddEdge(x, y)
+
+	tree := DominatorTree(root, g)
+
+	if !tree.HasEdge(root, x) {
+		t.Errorf("expected root -> x in the dominator tree")
+	}
+	if !tree.HasEdge(x, y) {
+		t.Errorf("expected x -> y in the dominator tree")
+	}
+}
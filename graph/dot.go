@@ -0,0 +1,119 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// DotNode is a Node whose Label is whatever was parsed out of a DOT file's
+// label= attribute (or, for nodes only ever seen in an edge, its raw DOT
+// identifier), rather than derived from its UUID like SimpleNode.
+type DotNode struct {
+	ID  uuid.UUID
+	Lbl string
+}
+
+func (d DotNode) Id() uuid.UUID {
+	return d.ID
+}
+
+func (d DotNode) Label() string {
+	return d.Lbl
+}
+
+var (
+	dotNodeDeclRe = regexp.MustCompile(`^(\w+)\s*\[label="([^"]*)"\]\s*;?$`)
+	dotEdgeRe     = regexp.MustCompile(`^(\w+)\s*->\s*(\w+)\s*(?:\[[^\]]*\])?\s*;?$`)
+	dotRankOpenRe = regexp.MustCompile(`^\{\s*rank\s*=\s*same\s*;?\s*(?://.*)?$`)
+	dotBareIDRe   = regexp.MustCompile(`^(\w+)\s*;?$`)
+)
+
+// LoadDOT parses a Graphviz digraph written in the subset produced by
+// SaveDAGDotAndPNG/SaveDAGDotAndPNGWithRanks: node declarations with a
+// label= attribute, "a -> b;" edges (optionally carrying a trailing
+// attribute list, e.g. the "[color=red]" SaveDAGDotAndPNG emits for cycle
+// edges), and optional "{ rank=same; ... }" blocks. It returns the parsed
+// graph, a lookup from each node's label to the Node built for it, and the
+// rank-same blocks decoded as layers (nil if the file had none) so callers
+// can feed them straight back into SaveDAGDotAndPNGWithRanks.
+func LoadDOT(r io.Reader) (*SimpleDigraph, map[string]Node, [][]Node, error) {
+	g := NewSimpleDigraph()
+	byID := make(map[string]Node)
+	byLabel := make(map[string]Node)
+
+	// nodeFor returns the Node for a DOT identifier, implicitly declaring one
+	// (Graphviz allows a node's first mention to be as an edge endpoint or a
+	// rank-block member, with no separate id [label=...] line) and
+	// registering it with g so it isn't an orphaned edge target missing
+	// from GetAllNodes.
+	nodeFor := func(id string) Node {
+		if n, ok := byID[id]; ok {
+			return n
+		}
+		n := DotNode{ID: uuid.New(), Lbl: id}
+		byID[id] = n
+		if _, taken := byLabel[id]; !taken {
+			byLabel[id] = n
+		}
+		g.AddNode(n)
+		return n
+	}
+
+	var layers [][]Node
+	var current []Node
+	inRank := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if inRank {
+			if line == "}" {
+				layers = append(layers, current)
+				current = nil
+				inRank = false
+				continue
+			}
+			if m := dotBareIDRe.FindStringSubmatch(line); m != nil {
+				current = append(current, nodeFor(m[1]))
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "digraph"), strings.HasPrefix(line, "rankdir"),
+			strings.HasPrefix(line, "node ["), line == "}":
+			continue
+		case dotRankOpenRe.MatchString(line):
+			inRank = true
+			continue
+		}
+
+		if m := dotNodeDeclRe.FindStringSubmatch(line); m != nil {
+			id, label := m[1], m[2]
+			n := DotNode{ID: uuid.New(), Lbl: label}
+			byID[id] = n
+			byLabel[label] = n
+			g.AddNode(n)
+			continue
+		}
+
+		if m := dotEdgeRe.FindStringSubmatch(line); m != nil {
+			g.AddEdge(nodeFor(m[1]), nodeFor(m[2]))
+			continue
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, nil, fmt.Errorf("reading dot: %w", err)
+	}
+
+	return g, byLabel, layers, nil
+}
@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadDOTParsesNodesEdgesAndRanks(t *testing.T) {
+	dot := `digraph G {
+  rankdir=LR;
+  node [shape=box, style=rounded, fontsize=12];
+  0 [label="A"];
+  1 [label="B"];
+  2 [label="C"];
+  { rank=same; // layer 0
+    0;
+    1;
+  }
+  0 -> 2;
+  1 -> 2;
+}
+`
+	g, byLabel, layers, err := LoadDOT(strings.NewReader(dot))
+	if err != nil {
+		t.Fatalf("LoadDOT returned error: %v", err)
+	}
+
+	if g.GetNodesCount() != 3 {
+		t.Fatalf("expected 3 nodes, got %d", g.GetNodesCount())
+	}
+
+	a, ok := byLabel["A"]
+	if !ok {
+		t.Fatalf("expected a node labeled A")
+	}
+	c, ok := byLabel["C"]
+	if !ok {
+		t.Fatalf("expected a node labeled C")
+	}
+	if !g.HasEdge(a, c) {
+		t.Errorf("expected edge A -> C")
+	}
+
+	if len(layers) != 1 || len(layers[0]) != 2 {
+		t.Fatalf("expected one rank-same layer of 2 nodes, got %v", layers)
+	}
+}
+
+func TestLoadDOTRegistersEdgeOnlyNode(t *testing.T) {
+	dot := `digraph G {
+  0 [label="A"];
+  0 -> implied;
+}
+`
+	g, _, _, err := LoadDOT(strings.NewReader(dot))
+	if err != nil {
+		t.Fatalf("LoadDOT returned error: %v", err)
+	}
+
+	if g.GetNodesCount() != 2 {
+		t.Fatalf("expected the edge-only node to be registered, got %d nodes", g.GetNodesCount())
+	}
+	if _, ok := TopologicalSortKahn(g); !ok {
+		t.Errorf("expected a valid topological order, got a false cycle report")
+	}
+}
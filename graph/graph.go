@@ -159,11 +159,17 @@ func SaveDAGDotAndPNG(sD SimpleDigraph, filenameBase string, useNames bool) erro
 		fmt.Fprintf(f, "  %d [label=\"%s\"];\n", i, node.Label())
 	}
 
-	// edges
+	// edges; any edge that closes a cycle is rendered in red instead of
+	// silently producing a DAG that isn't actually acyclic.
+	loopEdges := loopEdgeSet(&sD)
 	for i, u := range nodes {
 		for _, v := range sD.GetAdjacencyFromNode(u) {
 			j := idx[v.Id()]
-			fmt.Fprintf(f, "  %d -> %d;\n", i, j)
+			if loopEdges[Edge{From: u, To: v}] {
+				fmt.Fprintf(f, "  %d -> %d [color=red];\n", i, j)
+			} else {
+				fmt.Fprintf(f, "  %d -> %d;\n", i, j)
+			}
 		}
 	}
 	fmt.Fprintln(f, "}")
@@ -175,6 +181,16 @@ func SaveDAGDotAndPNG(sD SimpleDigraph, filenameBase string, useNames bool) erro
 	return nil
 }
 
+// loopEdgeSet returns the edges FindLoopEdges reports for g, as a set
+// keyed by Edge, for O(1) lookups while rendering.
+func loopEdgeSet(g GraphOperable) map[Edge]bool {
+	set := make(map[Edge]bool)
+	for _, e := range FindLoopEdges(g) {
+		set[e] = true
+	}
+	return set
+}
+
 // Like SaveDAGDotAndPNG, but lets you pass "ranks" (layers) to show nodes on the same rank.
 func SaveDAGDotAndPNGWithRanks(sD SimpleDigraph, filenameBase string, useNames bool, ranks [][]Node) error {
 	dotFilename := filenameBase + ".dot"
@@ -214,11 +230,17 @@ func SaveDAGDotAndPNGWithRanks(sD SimpleDigraph, filenameBase string, useNames b
 		fmt.Fprintln(f, "  }")
 	}
 
-	// edges
+	// edges; any edge that closes a cycle is rendered in red instead of
+	// silently producing a DAG that isn't actually acyclic.
+	loopEdges := loopEdgeSet(&sD)
 	for i, u := range nodes {
 		for _, v := range sD.GetAdjacencyFromNode(u) {
 			j := idx[v.Id()]
-			fmt.Fprintf(f, "  %d -> %d;\n", i, j)
+			if loopEdges[Edge{From: u, To: v}] {
+				fmt.Fprintf(f, "  %d -> %d [color=red];\n", i, j)
+			} else {
+				fmt.Fprintf(f, "  %d -> %d;\n", i, j)
+			}
 		}
 	}
 	fmt.Fprintln(f, "}")
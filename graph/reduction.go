@@ -0,0 +1,91 @@
+package graph
+
+import (
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// bitset is a fixed-size bit vector keyed by topological index, used by
+// TransitiveReduction to track "already reachable" without an O(V) set scan
+// per membership test.
+type bitset []uint64
+
+func newBitset(n int) bitset {
+	return make(bitset, (n+63)/64)
+}
+
+func (b bitset) set(i int) {
+	b[i/64] |= 1 << uint(i%64)
+}
+
+func (b bitset) test(i int) bool {
+	return b[i/64]&(1<<uint(i%64)) != 0
+}
+
+func (b bitset) or(other bitset) {
+	for i := range b {
+		b[i] |= other[i]
+	}
+}
+
+// TransitiveReduction returns the minimum-edge DAG with the same
+// reachability relation as g: an edge (u,v) is dropped whenever an
+// alternate path u->...->v of length >= 2 already exists.
+//
+// g must be acyclic; if TopologicalSortKahn reports a cycle,
+// TransitiveReduction returns nil.
+func TransitiveReduction(g GraphOperable) *SimpleDigraph {
+	order, ok := TopologicalSortKahn(g)
+	if !ok {
+		return nil
+	}
+
+	topoIndex := make(map[uuid.UUID]int, len(order))
+	for i, n := range order {
+		topoIndex[n.Id()] = i
+	}
+
+	reduced := NewSimpleDigraph()
+	for _, n := range order {
+		reduced.AddNode(n)
+	}
+
+	// reach[u] is the set of nodes reachable from u via edges already kept
+	// in the reduction. Processing nodes in reverse topological order
+	// guarantees reach[v] is final by the time a predecessor u considers v.
+	// Keyed by uuid.UUID rather than the Node value itself, the same way
+	// TopologicalSortStable is, since a Node isn't guaranteed to be
+	// comparable (task.Task embeds a []Actionable).
+	reach := make(map[uuid.UUID]bitset, len(order))
+	for i := len(order) - 1; i >= 0; i-- {
+		u := order[i]
+
+		// Successors must be considered nearest-first (ascending topoIndex):
+		// if a farther successor is also reachable through a nearer one, the
+		// nearer one's reach set (already final, since it was processed
+		// earlier in this reverse pass) must be folded in before the farther
+		// one is tested, or the result depends on g's edge-insertion order
+		// rather than graph structure.
+		succ := g.GetAdjacencyFromNode(u)
+		sorted := make([]Node, len(succ))
+		copy(sorted, succ)
+		slices.SortFunc(sorted, func(a, b Node) int {
+			return topoIndex[a.Id()] - topoIndex[b.Id()]
+		})
+
+		mine := newBitset(len(order))
+		for _, v := range sorted {
+			vi := topoIndex[v.Id()]
+			if mine.test(vi) {
+				continue // v is already reachable via a previously-kept successor
+			}
+			reduced.AddEdge(u, v)
+			mine.set(vi)
+			mine.or(reach[v.Id()])
+		}
+		reach[u.Id()] = mine
+	}
+
+	return reduced
+}
@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func namedNode(label string) Node {
+	return DotNode{ID: uuid.New(), Lbl: label}
+}
+
+func TestTransitiveReductionDropsRedundantEdge(t *testing.T) {
+	a, b, c := namedNode("A"), namedNode("B"), namedNode("C")
+
+	g := NewSimpleDigraph()
+	g.AddNode(a)
+	g.AddNode(b)
+	g.AddNode(c)
+	g.AddEdge(a, b)
+	g.AddEdge(b, c)
+	g.AddEdge(a, c) // redundant: A already reaches C via B
+
+	reduced := TransitiveReduction(g)
+
+	if !reduced.HasEdge(a, b) {
+		t.Errorf("expected A->B to survive the reduction")
+	}
+	if !reduced.HasEdge(b, c) {
+		t.Errorf("expected B->C to survive the reduction")
+	}
+	if reduced.HasEdge(a, c) {
+		t.Errorf("expected redundant A->C to be dropped")
+	}
+}
+
+func TestTransitiveReductionDropsRedundantEdgeAddedFirst(t *testing.T) {
+	a, b, c := namedNode("A"), namedNode("B"), namedNode("C")
+
+	g := NewSimpleDigraph()
+	g.AddNode(a)
+	g.AddNode(b)
+	g.AddNode(c)
+	g.AddEdge(a, c) // redundant: added before A->B, must not survive on that account
+	g.AddEdge(a, b)
+	g.AddEdge(b, c)
+
+	reduced := TransitiveReduction(g)
+
+	if !reduced.HasEdge(a, b) {
+		t.Errorf("expected A->B to survive the reduction")
+	}
+	if !reduced.HasEdge(b, c) {
+		t.Errorf("expected B->C to survive the reduction")
+	}
+	if reduced.HasEdge(a, c) {
+		t.Errorf("expected redundant A->C to be dropped regardless of edge-insertion order")
+	}
+}
+
+func TestTransitiveReductionRejectsCycle(t *testing.T) {
+	a, b := namedNode("A"), namedNode("B")
+
+	g := NewSimpleDigraph()
+	g.AddNode(a)
+	g.AddNode(b)
+	g.AddEdge(a, b)
+	g.AddEdge(b, a)
+
+	if reduced := TransitiveReduction(g); reduced != nil {
+		t.Errorf("expected nil for a cyclic graph, got %v", reduced)
+	}
+}
@@ -0,0 +1,77 @@
+package graph
+
+import (
+	"container/heap"
+
+	"github.com/google/uuid"
+)
+
+// ByLabel orders nodes by their Label(), for use with TopologicalSortStable.
+func ByLabel(a, b Node) bool {
+	return a.Label() < b.Label()
+}
+
+// ByUUID orders nodes by their Id(), for use with TopologicalSortStable.
+func ByUUID(a, b Node) bool {
+	return a.Id().String() < b.Id().String()
+}
+
+// nodeHeap is a container/heap priority queue over the nodes currently
+// ready to emit (in-degree zero), ordered by a caller-supplied less.
+type nodeHeap struct {
+	nodes []Node
+	less  func(a, b Node) bool
+}
+
+func (h nodeHeap) Len() int            { return len(h.nodes) }
+func (h nodeHeap) Less(i, j int) bool  { return h.less(h.nodes[i], h.nodes[j]) }
+func (h nodeHeap) Swap(i, j int)       { h.nodes[i], h.nodes[j] = h.nodes[j], h.nodes[i] }
+func (h *nodeHeap) Push(x interface{}) { h.nodes = append(h.nodes, x.(Node)) }
+func (h *nodeHeap) Pop() interface{} {
+	old := h.nodes
+	n := len(old)
+	item := old[n-1]
+	h.nodes = old[:n-1]
+	return item
+}
+
+// TopologicalSortStable is TopologicalSortKahn with a deterministic
+// tie-break: instead of a FIFO queue over map iteration order, ready nodes
+// (in-degree zero) sit in a container/heap priority queue, and whenever
+// more than one is ready, the one minimizing less is emitted next. Passing
+// ByLabel or ByUUID gives reproducible output across runs of an otherwise
+// identical graph.
+func TopologicalSortStable(g GraphOperable, less func(a, b Node) bool) ([]Node, bool) {
+	indeg := make(map[uuid.UUID]int)
+	for _, u := range g.GetAllNodes() {
+		indeg[u.Id()] = 0
+	}
+	for _, u := range g.GetAllNodes() {
+		for _, v := range g.GetAdjacencyFromNode(u) {
+			indeg[v.Id()]++
+		}
+	}
+
+	ready := &nodeHeap{less: less}
+	for _, u := range g.GetAllNodes() {
+		if indeg[u.Id()] == 0 {
+			heap.Push(ready, u)
+		}
+	}
+
+	order := make([]Node, 0, g.GetNodesCount())
+	for ready.Len() > 0 {
+		u := heap.Pop(ready).(Node)
+		order = append(order, u)
+		for _, v := range g.GetAdjacencyFromNode(u) {
+			indeg[v.Id()]--
+			if indeg[v.Id()] == 0 {
+				heap.Push(ready, v)
+			}
+		}
+	}
+	if len(order) != g.GetNodesCount() {
+		return nil, false // a cycle exists
+	}
+	return order, true
+}
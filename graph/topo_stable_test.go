@@ -0,0 +1,46 @@
+package graph
+
+import "testing"
+
+func TestTopologicalSortStableBreaksTiesByLabel(t *testing.T) {
+	b, a, c := namedNode("B"), namedNode("A"), namedNode("C")
+
+	g := NewSimpleDigraph()
+	// Added out of alphabetical order, so a FIFO queue over insertion order
+	// would emit B before A; ByLabel must not.
+	g.AddNode(b)
+	g.AddNode(a)
+	g.AddNode(c)
+	g.AddEdge(a, c)
+	g.AddEdge(b, c)
+
+	order, ok := TopologicalSortStable(g, ByLabel)
+	if !ok {
+		t.Fatalf("expected acyclic graph to sort")
+	}
+
+	labels := make([]string, len(order))
+	for i, n := range order {
+		labels[i] = n.Label()
+	}
+	want := []string{"A", "B", "C"}
+	for i, l := range want {
+		if labels[i] != l {
+			t.Fatalf("order = %v, want %v", labels, want)
+		}
+	}
+}
+
+func TestTopologicalSortStableRejectsCycle(t *testing.T) {
+	a, b := namedNode("A"), namedNode("B")
+
+	g := NewSimpleDigraph()
+	g.AddNode(a)
+	g.AddNode(b)
+	g.AddEdge(a, b)
+	g.AddEdge(b, a)
+
+	if _, ok := TopologicalSortStable(g, ByLabel); ok {
+		t.Fatalf("expected cycle to be rejected")
+	}
+}
@@ -0,0 +1,395 @@
+// Package exec runs a task.TaskGraph (or any graph.GraphOperable whose nodes
+// are *task.Task pointers) to completion: it layers the graph with
+// graph.KahnLayers, fans each layer out across a bounded worker pool, and
+// threads ActionOutput.Data from finished parents into the ActionInput of
+// their children.
+package exec
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moonstripe/workflow-dag/graph"
+	"github.com/moonstripe/workflow-dag/task"
+)
+
+// MergePolicy decides how a node's ActionInput is assembled from the
+// ActionOutput of its parents when more than one upstream node feeds it.
+type MergePolicy func(parents []task.ActionOutput) task.ActionInput
+
+// MergeOverwrite merges parent outputs in adjacency order; keys from a
+// later parent overwrite keys set by an earlier one. It is the default
+// MergePolicy.
+func MergeOverwrite(parents []task.ActionOutput) task.ActionInput {
+	in := task.ActionInput{}
+	for _, p := range parents {
+		for k, v := range p.Data {
+			in[k] = v
+		}
+	}
+	return in
+}
+
+// EventKind identifies the kind of lifecycle Event an Executor emits.
+type EventKind int
+
+const (
+	EventStarted EventKind = iota
+	EventFinished
+	EventFailed
+	EventRetrying
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventStarted:
+		return "started"
+	case EventFinished:
+		return "finished"
+	case EventFailed:
+		return "failed"
+	case EventRetrying:
+		return "retrying"
+	default:
+		return "unknown event"
+	}
+}
+
+// Event is a lifecycle notification published to an Executor's observer
+// channel as a run progresses.
+type Event struct {
+	Kind   EventKind
+	TaskID uuid.UUID
+	Status task.ActionStatus
+	Err    error
+}
+
+// RetryPolicy controls whether a failed action is retried and how long to
+// wait before the next attempt. The zero value performs no retries.
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     func(attempt int) time.Duration
+}
+
+func (r RetryPolicy) shouldRetry(attempt int) bool {
+	return attempt+1 < r.MaxAttempts
+}
+
+func (r RetryPolicy) backoff(attempt int) time.Duration {
+	if r.Backoff == nil {
+		return 0
+	}
+	return r.Backoff(attempt)
+}
+
+// Persister checkpoints the executor's state map so a crashed Run can be
+// resumed from the last set of Finished nodes.
+type Persister interface {
+	Save(state map[uuid.UUID]task.ActionOutput) error
+}
+
+// Executor runs the task.Task nodes of a graph.GraphOperable, respecting
+// dependency order and bounding parallelism with a worker pool.
+type Executor struct {
+	g           graph.GraphOperable
+	concurrency int
+	failFast    bool
+	merge       MergePolicy
+	retry       RetryPolicy
+	events      chan Event
+	persister   Persister
+}
+
+// Option configures an Executor returned by NewExecutor.
+type Option func(*Executor)
+
+// WithConcurrency bounds how many actions run at once. n <= 0 means
+// unbounded (every ready task in a layer is dispatched immediately).
+func WithConcurrency(n int) Option {
+	return func(e *Executor) { e.concurrency = n }
+}
+
+// WithFailFast controls whether a Failed node cancels the remaining run
+// (the default, true) or lets unaffected branches keep running to
+// completion while the overall run still reports an error.
+func WithFailFast(failFast bool) Option {
+	return func(e *Executor) { e.failFast = failFast }
+}
+
+// WithMergePolicy overrides how the ActionOutput.Data of multiple parents is
+// combined into a downstream node's ActionInput. Defaults to MergeOverwrite.
+func WithMergePolicy(m MergePolicy) Option {
+	return func(e *Executor) { e.merge = m }
+}
+
+// WithRetry configures per-action retry/backoff behavior on failure.
+func WithRetry(r RetryPolicy) Option {
+	return func(e *Executor) { e.retry = r }
+}
+
+// WithEvents gives the Executor a channel to publish lifecycle Events on.
+// Run closes the channel when the run ends, so the caller should only read
+// from it, never close it themselves.
+func WithEvents(ch chan Event) Option {
+	return func(e *Executor) { e.events = ch }
+}
+
+// WithPersister registers a hook invoked after every node finishes, so that
+// a crashed run can later be resumed by passing the saved state back into
+// Run's resume argument.
+func WithPersister(p Persister) Option {
+	return func(e *Executor) { e.persister = p }
+}
+
+// NewExecutor builds an Executor over g. g's nodes must be *task.Task
+// pointers; any node that isn't is skipped.
+func NewExecutor(g graph.GraphOperable, opts ...Option) *Executor {
+	e := &Executor{
+		g:           g,
+		concurrency: 0,
+		failFast:    true,
+		merge:       MergeOverwrite,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Run executes every node of the graph in dependency order, dispatching
+// each layer's ready tasks onto a bounded worker pool, and returns the
+// final ActionOutput for every node it ran.
+//
+// If resume is non-nil, any node in it whose ActionOutput.Status is
+// task.Finished is treated as already done and is skipped, along with all
+// of its actions; entries for any other status (e.g. a checkpoint taken
+// after a Failed node) are re-run.
+//
+// A Failed node's descendants never run, in either WithFailFast mode: with
+// the default failFast=true the whole run is cancelled as soon as one node
+// fails, which stops them same as everything else not yet dispatched; with
+// failFast=false the run keeps going, but any node transitively downstream
+// of the failure is individually skipped (it has no entry in the returned
+// state) since it would otherwise run against input silently missing that
+// parent's data. Branches unaffected by the failure run to completion
+// either way.
+func (e *Executor) Run(ctx context.Context, resume map[uuid.UUID]task.ActionOutput) (map[uuid.UUID]task.ActionOutput, error) {
+	layers := graph.KahnLayers(e.g)
+	parentsOf := parentIndex(e.g)
+
+	state := make(map[uuid.UUID]task.ActionOutput, len(resume))
+	for id, out := range resume {
+		if out.Status == task.Finished {
+			state[id] = out
+		}
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, e.poolSize())
+
+	var (
+		mu      sync.Mutex
+		stateMu sync.Mutex
+		runErr  error
+		// failed collects the ids of nodes that either failed outright or
+		// were skipped because a parent did, so the check below also
+		// propagates a failure past however many layers are downstream of
+		// it. Guarded by stateMu.
+		failed = make(map[uuid.UUID]bool)
+	)
+
+	for _, layer := range layers {
+		var wg sync.WaitGroup
+
+		for _, n := range layer {
+			t, ok := n.(*task.Task)
+			if !ok {
+				continue
+			}
+			if _, done := state[t.TaskID]; done {
+				continue
+			}
+			select {
+			case <-runCtx.Done():
+				continue
+			default:
+			}
+
+			if !e.failFast {
+				stateMu.Lock()
+				skip := hasFailedParent(t.TaskID, parentsOf, failed)
+				if skip {
+					failed[t.TaskID] = true
+				}
+				stateMu.Unlock()
+				if skip {
+					continue
+				}
+			}
+
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(t *task.Task) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				stateMu.Lock()
+				in := e.inputFor(t, state, parentsOf)
+				stateMu.Unlock()
+
+				e.emit(Event{Kind: EventStarted, TaskID: t.TaskID, Status: task.Running})
+				out := e.conduct(runCtx, t, in)
+
+				stateMu.Lock()
+				state[t.TaskID] = out
+				snapshot := cloneState(state)
+				stateMu.Unlock()
+
+				if e.persister != nil {
+					if err := e.persister.Save(snapshot); err != nil {
+						mu.Lock()
+						if runErr == nil {
+							runErr = fmt.Errorf("persist state after task %s: %w", t.TaskID, err)
+						}
+						mu.Unlock()
+					}
+				}
+
+				if out.Status == task.Failed {
+					e.emit(Event{Kind: EventFailed, TaskID: t.TaskID, Status: out.Status})
+					stateMu.Lock()
+					failed[t.TaskID] = true
+					stateMu.Unlock()
+					mu.Lock()
+					if runErr == nil {
+						runErr = fmt.Errorf("task %s failed", t.TaskID)
+					}
+					mu.Unlock()
+					if e.failFast {
+						cancel()
+					}
+				} else {
+					e.emit(Event{Kind: EventFinished, TaskID: t.TaskID, Status: out.Status})
+				}
+			}(t)
+		}
+
+		wg.Wait()
+
+		if e.failFast && runCtx.Err() != nil {
+			break
+		}
+	}
+
+	if e.events != nil {
+		close(e.events)
+	}
+	return state, runErr
+}
+
+// hasFailedParent reports whether any of id's direct parents are in failed.
+// Layers run in dependency order with a full wg.Wait barrier between them,
+// so by the time a layer's nodes are considered, every direct parent (which
+// lives in an earlier layer) has already been resolved into failed or not.
+func hasFailedParent(id uuid.UUID, parentsOf map[uuid.UUID][]uuid.UUID, failed map[uuid.UUID]bool) bool {
+	for _, pid := range parentsOf[id] {
+		if failed[pid] {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Executor) poolSize() int {
+	if e.concurrency > 0 {
+		return e.concurrency
+	}
+	return e.g.GetNodesCount()
+}
+
+func (e *Executor) emit(ev Event) {
+	if e.events != nil {
+		e.events <- ev
+	}
+}
+
+// parentIndex builds a uuid.UUID-keyed reverse adjacency index from g, so
+// that looking up a node's parents never has to key or compare by the raw
+// Node value itself (Task isn't comparable: it embeds a []Actionable,
+// which HasEdge/AddNode implementations backed by a map[Node]... would
+// panic on). It walks GetAllNodes/GetAdjacencyFromNode rather than
+// GetAdjacency for the same reason; see workflow.Dump.
+func parentIndex(g graph.GraphOperable) map[uuid.UUID][]uuid.UUID {
+	idx := make(map[uuid.UUID][]uuid.UUID)
+	for _, u := range g.GetAllNodes() {
+		for _, v := range g.GetAdjacencyFromNode(u) {
+			idx[v.Id()] = append(idx[v.Id()], u.Id())
+		}
+	}
+	return idx
+}
+
+// inputFor gathers the ActionOutput of t's parents (the nodes with an edge
+// into t, per parentsOf) and folds them into an ActionInput via the
+// Executor's MergePolicy. Callers must hold stateMu.
+func (e *Executor) inputFor(t *task.Task, state map[uuid.UUID]task.ActionOutput, parentsOf map[uuid.UUID][]uuid.UUID) task.ActionInput {
+	parents := make([]task.ActionOutput, 0)
+	for _, pid := range parentsOf[t.TaskID] {
+		if out, ok := state[pid]; ok {
+			parents = append(parents, out)
+		}
+	}
+	return e.merge(parents)
+}
+
+// conduct runs every Actionable attached to t in sequence, retrying each
+// one per the Executor's RetryPolicy, and folds the results into a single
+// ActionOutput for the task.
+func (e *Executor) conduct(ctx context.Context, t *task.Task, in task.ActionInput) task.ActionOutput {
+	out := task.ActionOutput{ActionId: t.TaskID, Status: task.Finished, Data: map[string]string{}}
+
+	for _, action := range t.Actions {
+		var attemptOut task.ActionOutput
+		for attempt := 0; ; attempt++ {
+			if ctx.Err() != nil {
+				return task.ActionOutput{ActionId: t.TaskID, Status: task.Failed, Data: out.Data}
+			}
+			attemptOut = action.Conduct(in)
+			if attemptOut.Status != task.Failed || !e.retry.shouldRetry(attempt) {
+				break
+			}
+			e.emit(Event{Kind: EventRetrying, TaskID: t.TaskID, Status: attemptOut.Status})
+			if d := e.retry.backoff(attempt); d > 0 {
+				select {
+				case <-time.After(d):
+				case <-ctx.Done():
+					return task.ActionOutput{ActionId: t.TaskID, Status: task.Failed, Data: out.Data}
+				}
+			}
+		}
+
+		for k, v := range attemptOut.Data {
+			out.Data[k] = v
+			in[k] = v
+		}
+		if attemptOut.Status == task.Failed {
+			out.Status = task.Failed
+			break
+		}
+	}
+
+	return out
+}
+
+func cloneState(state map[uuid.UUID]task.ActionOutput) map[uuid.UUID]task.ActionOutput {
+	clone := make(map[uuid.UUID]task.ActionOutput, len(state))
+	for k, v := range state {
+		clone[k] = v
+	}
+	return clone
+}
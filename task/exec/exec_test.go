@@ -0,0 +1,218 @@
+package exec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moonstripe/workflow-dag/task"
+)
+
+// fakeAction returns a fixed status, optionally merging data into whatever
+// ActionInput it's given, and optionally counting how many times it runs.
+type fakeAction struct {
+	name   string
+	status task.ActionStatus
+	data   map[string]string
+	calls  *int
+}
+
+func (f fakeAction) String() string { return f.name }
+
+func (f fakeAction) Conduct(in task.ActionInput) task.ActionOutput {
+	if f.calls != nil {
+		*f.calls++
+	}
+	out := make(map[string]string, len(in)+len(f.data))
+	for k, v := range in {
+		out[k] = v
+	}
+	for k, v := range f.data {
+		out[k] = v
+	}
+	return task.ActionOutput{Status: f.status, Data: out}
+}
+
+// recordingAction captures the ActionInput it was called with, so a test
+// can assert on what an upstream task threaded downstream.
+type recordingAction struct {
+	record *task.ActionInput
+}
+
+func (r recordingAction) String() string { return "record" }
+
+func (r recordingAction) Conduct(in task.ActionInput) task.ActionOutput {
+	*r.record = in
+	return task.ActionOutput{Status: task.Finished, Data: map[string]string{}}
+}
+
+func TestExecutorThreadsDataToDownstream(t *testing.T) {
+	producer := &task.Task{TaskID: uuid.New(), Actions: []task.Actionable{
+		fakeAction{name: "produce", status: task.Finished, data: map[string]string{"x": "1"}},
+	}}
+
+	var seenInput task.ActionInput
+	consumer := &task.Task{TaskID: uuid.New(), Actions: []task.Actionable{
+		recordingAction{record: &seenInput},
+	}}
+
+	g := task.NewTaskGraph()
+	g.AddNode(producer)
+	g.AddNode(consumer)
+	g.AddEdge(producer, consumer)
+
+	e := NewExecutor(g)
+	state, err := e.Run(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if state[producer.TaskID].Status != task.Finished {
+		t.Fatalf("expected producer to finish, got %v", state[producer.TaskID].Status)
+	}
+	if seenInput["x"] != "1" {
+		t.Fatalf("expected consumer to see x=1 from its parent, got %v", seenInput)
+	}
+}
+
+func TestExecutorFailFastSkipsDownstream(t *testing.T) {
+	root := &task.Task{TaskID: uuid.New(), Actions: []task.Actionable{
+		fakeAction{name: "root", status: task.Failed},
+	}}
+	child := &task.Task{TaskID: uuid.New(), Actions: []task.Actionable{
+		fakeAction{name: "child", status: task.Finished},
+	}}
+
+	g := task.NewTaskGraph()
+	g.AddNode(root)
+	g.AddNode(child)
+	g.AddEdge(root, child)
+
+	e := NewExecutor(g) // failFast defaults to true
+	state, err := e.Run(context.Background(), nil)
+	if err == nil {
+		t.Fatalf("expected an error from the failed root task")
+	}
+	if _, ran := state[child.TaskID]; ran {
+		t.Fatalf("expected the downstream task to be skipped after fail-fast cancellation")
+	}
+}
+
+func TestExecutorResumeOnlySkipsFinishedTasks(t *testing.T) {
+	var calls int
+	t1 := &task.Task{TaskID: uuid.New(), Actions: []task.Actionable{
+		fakeAction{name: "t1", status: task.Finished, calls: &calls},
+	}}
+	t2 := &task.Task{TaskID: uuid.New(), Actions: []task.Actionable{
+		fakeAction{name: "t2", status: task.Finished, calls: &calls},
+	}}
+
+	g := task.NewTaskGraph()
+	g.AddNode(t1)
+	g.AddNode(t2)
+
+	resume := map[uuid.UUID]task.ActionOutput{
+		t1.TaskID: {Status: task.Finished},
+		t2.TaskID: {Status: task.Failed}, // a checkpoint taken after t2 failed
+	}
+
+	e := NewExecutor(g)
+	state, err := e.Run(context.Background(), resume)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected only the previously-Failed task to re-run, got %d calls", calls)
+	}
+	if state[t1.TaskID].Status != task.Finished {
+		t.Fatalf("expected the resumed Finished task to stay Finished")
+	}
+	if state[t2.TaskID].Status != task.Finished {
+		t.Fatalf("expected the re-run task to finish this time")
+	}
+}
+
+func TestExecutorContinueModeSkipsOnlyFailedDescendants(t *testing.T) {
+	var siblingCalls, childCalls int
+	root := &task.Task{TaskID: uuid.New(), Actions: []task.Actionable{
+		fakeAction{name: "root", status: task.Failed},
+	}}
+	child := &task.Task{TaskID: uuid.New(), Actions: []task.Actionable{
+		fakeAction{name: "child", status: task.Finished, calls: &childCalls},
+	}}
+	sibling := &task.Task{TaskID: uuid.New(), Actions: []task.Actionable{
+		fakeAction{name: "sibling", status: task.Finished, calls: &siblingCalls},
+	}}
+
+	g := task.NewTaskGraph()
+	g.AddNode(root)
+	g.AddNode(child)
+	g.AddNode(sibling)
+	g.AddEdge(root, child) // child depends on the failing root
+	// sibling has no edges at all: unaffected by root's failure
+
+	e := NewExecutor(g, WithFailFast(false))
+	state, err := e.Run(context.Background(), nil)
+	if err == nil {
+		t.Fatalf("expected an error from the failed root task")
+	}
+	if _, ran := state[child.TaskID]; ran {
+		t.Fatalf("expected child to be skipped as a descendant of the failed root")
+	}
+	if childCalls != 0 {
+		t.Fatalf("expected child's action to never run, got %d calls", childCalls)
+	}
+	if state[sibling.TaskID].Status != task.Finished {
+		t.Fatalf("expected the unaffected sibling branch to run to completion, got %v", state[sibling.TaskID])
+	}
+	if siblingCalls != 1 {
+		t.Fatalf("expected sibling's action to run exactly once, got %d calls", siblingCalls)
+	}
+}
+
+func TestExecutorRetriesUntilMaxAttempts(t *testing.T) {
+	var calls int
+	flaky := &task.Task{TaskID: uuid.New(), Actions: []task.Actionable{
+		fakeAction{name: "flaky", status: task.Failed, calls: &calls},
+	}}
+
+	g := task.NewTaskGraph()
+	g.AddNode(flaky)
+
+	e := NewExecutor(g, WithRetry(RetryPolicy{MaxAttempts: 3}))
+	state, err := e.Run(context.Background(), nil)
+	if err == nil {
+		t.Fatalf("expected an error from the still-failing task")
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls)
+	}
+	if state[flaky.TaskID].Status != task.Failed {
+		t.Fatalf("expected the final status to be Failed, got %v", state[flaky.TaskID].Status)
+	}
+}
+
+func TestExecutorEmitsLifecycleEvents(t *testing.T) {
+	solo := &task.Task{TaskID: uuid.New(), Actions: []task.Actionable{
+		fakeAction{name: "solo", status: task.Finished},
+	}}
+
+	g := task.NewTaskGraph()
+	g.AddNode(solo)
+
+	events := make(chan Event, 10)
+	e := NewExecutor(g, WithEvents(events))
+	if _, err := e.Run(context.Background(), nil); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	var kinds []EventKind
+	for ev := range events {
+		if ev.TaskID != solo.TaskID {
+			t.Fatalf("expected every event to reference solo, got %v", ev.TaskID)
+		}
+		kinds = append(kinds, ev.Kind)
+	}
+	if len(kinds) != 2 || kinds[0] != EventStarted || kinds[1] != EventFinished {
+		t.Fatalf("expected [Started, Finished], got %v", kinds)
+	}
+}
@@ -1,6 +1,8 @@
 package task
 
 import (
+	"errors"
+
 	"github.com/google/uuid"
 	"github.com/moonstripe/workflow-dag/graph"
 )
@@ -50,15 +52,102 @@ type Task struct {
 	Actions []Actionable `json:"actions"`
 }
 
-func (t Task) Id() uuid.UUID {
+func (t *Task) Id() uuid.UUID {
 	return t.TaskID
 }
 
-func (t Task) Label() string {
+func (t *Task) Label() string {
 	return t.TaskID.String()[:8]
 }
 
+// TaskGraph is a graph.GraphOperable whose nodes are *Task pointers, ready
+// to be handed to an exec.Executor or workflow compiler.
+//
+// Task embeds a slice (Actions), so the Task value itself isn't comparable;
+// Id/Label are defined on *Task rather than Task so that a *TaskGraph's
+// nodes are always pointers, which are safe to use as a map key (unlike the
+// Task value they point to). Callers must add nodes as &Task{...}, not
+// Task{...}. Lookups within TaskGraph itself still go through uuid.UUID
+// rather than the Node value, the same way SimpleDigraph's helper functions
+// key off node.Id().
 type TaskGraph struct {
-	tasks []graph.Node
-	adj   map[graph.Node][]graph.Node
+	tasks   []graph.Node
+	byID    map[uuid.UUID]graph.Node
+	adjByID map[uuid.UUID][]uuid.UUID
+}
+
+func NewTaskGraph() *TaskGraph {
+	return &TaskGraph{
+		tasks:   []graph.Node{},
+		byID:    make(map[uuid.UUID]graph.Node),
+		adjByID: make(map[uuid.UUID][]uuid.UUID),
+	}
+}
+
+func (tg *TaskGraph) AddNode(n graph.Node) {
+	tg.tasks = append(tg.tasks, n)
+	tg.byID[n.Id()] = n
+	tg.adjByID[n.Id()] = make([]uuid.UUID, 0)
+}
+
+func (tg *TaskGraph) GetNode(id uuid.UUID) (graph.Node, error) {
+	if n, ok := tg.byID[id]; ok {
+		return n, nil
+	}
+	return nil, errors.New("could not find node here")
+}
+
+func (tg *TaskGraph) GetNodesCount() int {
+	return len(tg.tasks)
+}
+
+func (tg *TaskGraph) GetAllNodes() []graph.Node {
+	return tg.tasks
+}
+
+func (tg *TaskGraph) AddEdge(from, to graph.Node) {
+	tg.adjByID[from.Id()] = append(tg.adjByID[from.Id()], to.Id())
+}
+
+func (tg *TaskGraph) RemoveEdge(from, to graph.Node) {
+	newAdj := make([]uuid.UUID, 0)
+	for _, id := range tg.adjByID[from.Id()] {
+		if id != to.Id() {
+			newAdj = append(newAdj, id)
+		}
+	}
+	tg.adjByID[from.Id()] = newAdj
+}
+
+func (tg *TaskGraph) HasEdge(from, to graph.Node) bool {
+	for _, id := range tg.adjByID[from.Id()] {
+		if id == to.Id() {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAdjacency satisfies graph.GraphOperable. It's safe to call on a
+// TaskGraph: nodes are *Task pointers, and a pointer is comparable even
+// though the Task it points to (which embeds a []Actionable) is not.
+func (tg *TaskGraph) GetAdjacency() map[graph.Node][]graph.Node {
+	adj := make(map[graph.Node][]graph.Node, len(tg.adjByID))
+	for _, n := range tg.tasks {
+		adj[n] = tg.GetAdjacencyFromNode(n)
+	}
+	return adj
+}
+
+func (tg *TaskGraph) GetAdjacencyFromNode(u graph.Node) []graph.Node {
+	ids := tg.adjByID[u.Id()]
+	nodes := make([]graph.Node, 0, len(ids))
+	for _, id := range ids {
+		nodes = append(nodes, tg.byID[id])
+	}
+	return nodes
+}
+
+func (tg *TaskGraph) IsDirected() bool {
+	return true
 }
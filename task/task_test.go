@@ -0,0 +1,27 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// TestTaskGraphGetAdjacencyDoesNotPanic is a regression test: Task embeds a
+// []Actionable, so GetAdjacency used to panic the moment it tried to key a
+// map literal off the Task value itself. Nodes are now *Task pointers,
+// which are comparable, so this must succeed.
+func TestTaskGraphGetAdjacencyDoesNotPanic(t *testing.T) {
+	a := &Task{TaskID: uuid.New()}
+	b := &Task{TaskID: uuid.New()}
+
+	g := NewTaskGraph()
+	g.AddNode(a)
+	g.AddNode(b)
+	g.AddEdge(a, b)
+
+	adj := g.GetAdjacency()
+	children := adj[a]
+	if len(children) != 1 || children[0].Id() != b.TaskID {
+		t.Fatalf("expected a's adjacency to be [b], got %v", children)
+	}
+}
@@ -0,0 +1,165 @@
+// Package workflow compiles a declarative YAML description of named steps
+// and their "needs:" dependencies into a task.TaskGraph, so callers don't
+// have to hand-call graph.AddNode/AddEdge to describe a pipeline.
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"github.com/moonstripe/workflow-dag/graph"
+	"github.com/moonstripe/workflow-dag/task"
+)
+
+// ActionFactory builds an Actionable from a step's `with:` config. Register
+// one per `uses:` value with RegisterAction before calling LoadFile.
+type ActionFactory func(cfg map[string]string) task.Actionable
+
+var registry = map[string]ActionFactory{}
+
+// RegisterAction makes an ActionFactory available to LoadFile under the
+// given `uses:` name. Registering the same name twice overwrites the
+// previous factory.
+func RegisterAction(uses string, factory ActionFactory) {
+	registry[uses] = factory
+}
+
+// Step is one entry of a workflow File: a named unit of work, the action it
+// `uses`, the steps it `needs`, and the config passed to that action `with`.
+type Step struct {
+	Name  string            `yaml:"name"`
+	Uses  string            `yaml:"uses"`
+	With  map[string]string `yaml:"with,omitempty"`
+	Needs []string          `yaml:"needs,omitempty"`
+}
+
+// File is the top-level shape of a workflow YAML document.
+type File struct {
+	Steps []Step `yaml:"steps"`
+}
+
+// withInputAction decorates an Actionable so that a step's `with:` config is
+// always present in its ActionInput, merged underneath whatever the
+// executor threads in from upstream nodes.
+type withInputAction struct {
+	task.Actionable
+	with task.ActionInput
+}
+
+func (w withInputAction) Conduct(aI task.ActionInput) task.ActionOutput {
+	merged := make(task.ActionInput, len(w.with)+len(aI))
+	for k, v := range w.with {
+		merged[k] = v
+	}
+	for k, v := range aI {
+		merged[k] = v
+	}
+	return w.Actionable.Conduct(merged)
+}
+
+// LoadFile parses the workflow YAML at path and compiles it into a
+// task.TaskGraph: every step becomes a task.Task node (its single action
+// built via the ActionFactory registered under its `uses:` name), and every
+// `needs:` entry becomes an edge from the dependency to the step.
+func LoadFile(path string) (*task.TaskGraph, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading workflow file: %w", err)
+	}
+
+	var f File
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parsing workflow yaml: %w", err)
+	}
+
+	return compile(f)
+}
+
+func compile(f File) (*task.TaskGraph, error) {
+	ids := make(map[string]uuid.UUID, len(f.Steps))
+	for _, s := range f.Steps {
+		if _, dup := ids[s.Name]; dup {
+			return nil, fmt.Errorf("workflow: duplicate step name %q", s.Name)
+		}
+		ids[s.Name] = uuid.New()
+	}
+
+	for _, s := range f.Steps {
+		for _, need := range s.Needs {
+			if _, ok := ids[need]; !ok {
+				return nil, fmt.Errorf("workflow: step %q needs unknown step %q", s.Name, need)
+			}
+		}
+	}
+
+	nodes := make(map[string]*task.Task, len(f.Steps))
+	for _, s := range f.Steps {
+		factory, ok := registry[s.Uses]
+		if !ok {
+			return nil, fmt.Errorf("workflow: step %q uses unregistered action %q", s.Name, s.Uses)
+		}
+		action := withInputAction{Actionable: factory(s.With), with: task.ActionInput(s.With)}
+		nodes[s.Name] = &task.Task{
+			TaskID:  ids[s.Name],
+			Actions: []task.Actionable{action},
+		}
+	}
+
+	g := task.NewTaskGraph()
+	for _, s := range f.Steps {
+		g.AddNode(nodes[s.Name])
+	}
+	for _, s := range f.Steps {
+		for _, need := range s.Needs {
+			g.AddEdge(nodes[need], nodes[s.Name])
+		}
+	}
+
+	if _, ok := graph.TopologicalSortKahn(g); !ok {
+		return nil, errors.New("workflow: needs form a cycle")
+	}
+
+	return g, nil
+}
+
+// Dump renders g back into workflow YAML, in topological order. Since
+// task.Task carries no step name, the dumped step names fall back to each
+// node's Label (its UUID prefix) and `uses:` to its action's String(); round
+// tripping a file loaded with LoadFile will not reproduce the original step
+// names.
+func Dump(g *task.TaskGraph) ([]byte, error) {
+	order, ok := graph.TopologicalSortKahn(g)
+	if !ok {
+		return nil, errors.New("workflow: graph contains a cycle")
+	}
+
+	parents := make(map[uuid.UUID][]string)
+	for _, u := range g.GetAllNodes() {
+		for _, v := range g.GetAdjacencyFromNode(u) {
+			parents[v.Id()] = append(parents[v.Id()], u.Label())
+		}
+	}
+
+	f := File{Steps: make([]Step, 0, len(order))}
+	for _, n := range order {
+		t, ok := n.(*task.Task)
+		if !ok {
+			continue
+		}
+		uses := ""
+		if len(t.Actions) > 0 {
+			uses = t.Actions[0].String()
+		}
+		f.Steps = append(f.Steps, Step{
+			Name:  n.Label(),
+			Uses:  uses,
+			Needs: parents[t.TaskID],
+		})
+	}
+
+	return yaml.Marshal(f)
+}
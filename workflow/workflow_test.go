@@ -0,0 +1,97 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moonstripe/workflow-dag/task"
+)
+
+type fakeAction struct {
+	cfg map[string]string
+}
+
+func (f fakeAction) String() string { return "fake" }
+
+func (f fakeAction) Conduct(in task.ActionInput) task.ActionOutput {
+	return task.ActionOutput{Status: task.Finished, Data: map[string]string{}}
+}
+
+func writeWorkflow(t *testing.T, body string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "workflow.yaml")
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("writing workflow fixture: %v", err)
+	}
+	return path
+}
+
+// TestLoadFileDoesNotPanicOnRealTasks is a regression test: task.Task
+// embeds a []Actionable, so compiling steps into a task.TaskGraph used to
+// panic the moment AddNode tried to key adjacency off the Task value
+// itself.
+func TestLoadFileDoesNotPanicOnRealTasks(t *testing.T) {
+	RegisterAction("shell", func(cfg map[string]string) task.Actionable {
+		return fakeAction{cfg: cfg}
+	})
+
+	path := writeWorkflow(t, `
+steps:
+  - name: build
+    uses: shell
+    with:
+      cmd: "go build ./..."
+  - name: test
+    uses: shell
+    needs: [build]
+    with:
+      cmd: "go test ./..."
+`)
+
+	g, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile returned error: %v", err)
+	}
+	if g.GetNodesCount() != 2 {
+		t.Fatalf("expected 2 steps to compile into 2 nodes, got %d", g.GetNodesCount())
+	}
+}
+
+func TestLoadFileRejectsCycle(t *testing.T) {
+	RegisterAction("shell", func(cfg map[string]string) task.Actionable {
+		return fakeAction{cfg: cfg}
+	})
+
+	path := writeWorkflow(t, `
+steps:
+  - name: a
+    uses: shell
+    needs: [b]
+  - name: b
+    uses: shell
+    needs: [a]
+`)
+
+	if _, err := LoadFile(path); err == nil {
+		t.Fatalf("expected a cycle between a and b to be rejected")
+	}
+}
+
+func TestLoadFileRejectsUnknownNeeds(t *testing.T) {
+	RegisterAction("shell", func(cfg map[string]string) task.Actionable {
+		return fakeAction{cfg: cfg}
+	})
+
+	path := writeWorkflow(t, `
+steps:
+  - name: a
+    uses: shell
+    needs: [missing]
+`)
+
+	if _, err := LoadFile(path); err == nil {
+		t.Fatalf("expected an unknown needs reference to be rejected")
+	}
+}